@@ -2,8 +2,11 @@ package i2c
 
 import (
 	"errors"
+	"fmt"
+	"image"
 	"strconv"
 	"strings"
+	"sync"
 
 	"gobot.io/x/gobot"
 )
@@ -16,6 +19,8 @@ const (
 	ht16k33RegBlink           = 0x80
 	ht16k33RegDisplay         = 0x80
 	ht16k33RegBrightness      = 0xE0
+	ht16k33RegKeyData         = 0x40
+	ht16k33RegInt             = 0x60
 )
 
 // settings
@@ -26,6 +31,34 @@ const (
 	ht16k33Off               = 0x00
 )
 
+// PanelType identifies which Adafruit LED Backpack variant is wired to the
+// HT16K33. All three variants share the same chip and the same 16-byte
+// display RAM, they only differ in how that RAM maps to visible segments, so
+// a single driver can support them by picking the right encoding at write
+// time.
+type PanelType uint8
+
+const (
+	// HT16K33Seg7 is the 7-segment 4-digit backpack (the default).
+	HT16K33Seg7 PanelType = iota
+	// HT16K33Seg14 is the 14-segment 4-character alphanumeric backpack.
+	HT16K33Seg14
+	// HT16K33Matrix8x8 is the 8x8 bicolor/monochrome matrix backpack.
+	HT16K33Matrix8x8
+)
+
+// BlinkRate is the display blink frequency, as defined by the HT16K33
+// datasheet's display setup command.
+type BlinkRate byte
+
+// Blink rates supported by the HT16K33 display setup command.
+const (
+	BlinkOff    BlinkRate = 0x00
+	Blink2Hz    BlinkRate = 0x01
+	Blink1Hz    BlinkRate = 0x02
+	BlinkHalfHz BlinkRate = 0x03
+)
+
 // digits calculated from converting binary to uint16, where binary positions
 // map to the following led panel segments:
 //
@@ -54,23 +87,134 @@ var digit = []uint16{
 	0x00EF, // 9
 }
 
+// hex extends digit with the additional A-F glyphs needed by WriteHex. 0-9
+// reuse the existing digit encodings; only 10-15 (A-F) are new.
+var hex = []uint16{
+	digit[0], digit[1], digit[2], digit[3],
+	digit[4], digit[5], digit[6], digit[7],
+	digit[8], digit[9],
+	0x0077, // A
+	0x007C, // b
+	0x0039, // C
+	0x005E, // d
+	0x0079, // E
+	0x0071, // F
+}
+
+// minusGlyph lights only segment 6 (the middle bar), used to render the
+// leading sign in WriteSigned and WriteFloat.
+const minusGlyph uint16 = 1 << 6
+
 var displayBuffer = make([]byte, 8)
 
+// seg14Font maps ASCII characters, starting at the space character (0x20),
+// to their 14-segment display encoding; bits here are part of each
+// character's actual segment pattern, not a decimal point (on real 14-segment
+// alphanumeric backpacks the DP is its own segment, bit 14, which this font
+// does not set). seg14Glyph folds lowercase letters onto this table and
+// reports any other character before space or after 'Z' as having no glyph,
+// so callers skip it. WriteFloat does not use this table; it only
+// supports HT16K33Seg7 panels.
+var seg14Font = []uint16{
+	0x0000, // space
+	0x0086, // !
+	0x0220, // "
+	0x12CE, // #
+	0x12ED, // $
+	0x3F52, // %
+	0x235D, // &
+	0x0400, // '
+	0x2400, // (
+	0x0900, // )
+	0x3FC0, // *
+	0x12C0, // +
+	0x0800, // ,
+	0x00C0, // -
+	0x0000, // .
+	0x0C00, // /
+	0x0C3F, // 0
+	0x0006, // 1
+	0x00DB, // 2
+	0x004F, // 3
+	0x00E6, // 4
+	0x00ED, // 5
+	0x00FD, // 6
+	0x0007, // 7
+	0x00FF, // 8
+	0x00EF, // 9
+	0x1200, // :
+	0x0A00, // ;
+	0x2440, // <
+	0x00C8, // =
+	0x0980, // >
+	0x1083, // ?
+	0x02BB, // @
+	0x00F7, // A
+	0x128F, // B
+	0x0039, // C
+	0x120F, // D
+	0x00F9, // E
+	0x00F1, // F
+	0x00BD, // G
+	0x00F6, // H
+	0x1209, // I
+	0x001E, // J
+	0x2470, // K
+	0x0038, // L
+	0x0536, // M
+	0x2136, // N
+	0x003F, // O
+	0x00F3, // P
+	0x203F, // Q
+	0x20F3, // R
+	0x00ED, // S
+	0x1201, // T
+	0x003E, // U
+	0x0C30, // V
+	0x2836, // W
+	0x2D00, // X
+	0x1500, // Y
+	0x0C09, // Z
+}
+
 // Errors
 var (
 	ErrNumberTooBig       = errors.New("number must be less than 10,000")
 	ErrDigitTooBig        = errors.New("digit must be less than 10")
 	ErrBinaryTooBig       = errors.New("value too big, maximum 65535")
 	ErrPositionOutOfRange = errors.New("position must be 0 - 3")
+	ErrWrongPanelType     = errors.New("operation not supported by the configured panel type")
 )
 
-// HT16K33Driver is a Driver for the Adafruit LED Backpack
+// ErrValueOutOfRange is returned by WriteFloat and WriteSigned when a value
+// cannot be displayed in the available digit positions. It
+// wraps the offending value so callers can decide whether to scroll or
+// truncate it rather than just failing.
+type ErrValueOutOfRange struct {
+	Value interface{}
+}
+
+func (e *ErrValueOutOfRange) Error() string {
+	return fmt.Sprintf("value %v out of range for display", e.Value)
+}
+
+// HT16K33Driver is a Driver for the Adafruit LED Backpack family of displays
 // https://learn.adafruit.com/adafruit-led-backpack
+//
+// By default it drives the 7-segment 4-digit backpack; use WithPanelType to
+// select the 14-segment alphanumeric or 8x8 matrix variants instead.
 type HT16K33Driver struct {
 	name       string
 	connector  Connector
 	connection Connection
+	panelType  PanelType
+	displayOn  bool
+	blinkRate  BlinkRate
+	matrix     [8]byte
+	pollMutex  sync.Mutex
+	pollHalt   chan struct{}
 	Config
+	gobot.Eventer
 }
 
 // NewHT16K33Driver creates a new driver with specified i2c interface
@@ -80,21 +224,36 @@ type HT16K33Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithPanelType(PanelType):	panel variant wired to the chip, defaults to HT16K33Seg7
 //
 func NewHT16K33Driver(a Connector, options ...func(Config)) *HT16K33Driver {
 	d := &HT16K33Driver{
 		name:      gobot.DefaultName("HT16K33"),
 		connector: a,
 		Config:    NewConfig(),
+		Eventer:   gobot.NewEventer(),
 	}
 
 	for _, option := range options {
 		option(d)
 	}
 
+	d.AddEvent("button.press")
+	d.AddEvent("button.release")
+
 	return d
 }
 
+// WithPanelType sets which Adafruit LED Backpack variant is wired to the
+// HT16K33.
+func WithPanelType(t PanelType) func(Config) {
+	return func(c Config) {
+		if d, ok := c.(*HT16K33Driver); ok {
+			d.panelType = t
+		}
+	}
+}
+
 // Name returns the name for this Driver
 func (h *HT16K33Driver) Name() string { return h.name }
 
@@ -138,17 +297,33 @@ func (h *HT16K33Driver) Start() (err error) {
 	return nil
 }
 
-// Halt returns true if devices is halted successfully
-func (h *HT16K33Driver) Halt() (err error) { return }
+// Halt stops any active key polling and returns nil
+func (h *HT16K33Driver) Halt() (err error) {
+	h.StopPollingKeys()
+	return nil
+}
 
 // SetDisplay turns the display on if on is true, otherwise turns it off
 func (h *HT16K33Driver) SetDisplay(on bool) error {
+	h.displayOn = on
+	return h.writeDisplaySetup()
+}
 
+// SetBlinkRate sets the display blink rate to one of BlinkOff, Blink2Hz,
+// Blink1Hz or BlinkHalfHz.
+func (h *HT16K33Driver) SetBlinkRate(rate BlinkRate) error {
+	h.blinkRate = rate
+	return h.writeDisplaySetup()
+}
+
+// writeDisplaySetup writes the combined display on/off and blink rate bits,
+// since the HT16K33 exposes both through the single display setup command.
+func (h *HT16K33Driver) writeDisplaySetup() error {
 	var v byte = ht16k33Off
-	if on {
+	if h.displayOn {
 		v = ht16k33DisplayOn
 	}
-	return h.connection.WriteByte(ht16k33RegDisplay | v)
+	return h.connection.WriteByte(ht16k33RegDisplay | v | (byte(h.blinkRate) << 1))
 }
 
 // SetBrightness sets the display brightness between 0 (off) and 15 (maximum)
@@ -224,6 +399,174 @@ func (h *HT16K33Driver) WriteDigit(pos uint8, d int) error {
 	return h.connection.WriteWordData(pos*2, digit[d])
 }
 
+// writeHexDigit writes a raw glyph word to position pos (0-3), optionally
+// setting bit 7 (the decimal point segment).
+func (h *HT16K33Driver) writeHexDigit(pos uint8, w uint16, dp bool) error {
+	if pos > 3 {
+		return ErrPositionOutOfRange
+	}
+
+	if dp {
+		w |= 1 << 7
+	}
+
+	// Skip colon at position 2
+	if pos == 2 || pos == 3 {
+		pos++
+	}
+
+	return h.connection.WriteWordData(pos*2, w)
+}
+
+// WriteHex displays n as 4 hexadecimal digits, zero-padded, using the A-F
+// glyphs from the hex font for values 10-15. WriteHex is only supported on
+// HT16K33Seg7 panels.
+func (h *HT16K33Driver) WriteHex(n uint16) error {
+	if h.panelType != HT16K33Seg7 {
+		return ErrWrongPanelType
+	}
+
+	if err := h.Clear(); err != nil {
+		return err
+	}
+
+	s := fmt.Sprintf("%04x", n)
+	for pos, c := range s {
+		d, err := strconv.ParseUint(string(c), 16, 8)
+		if err != nil {
+			return err
+		}
+		if err := h.writeHexDigit(uint8(pos), hex[d], false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteSigned displays n, in the range -999..9999. Negative values reserve
+// position 0 for a leading minus sign, rendered using segment 6 only, so
+// only 3 digits are available for the magnitude. Values outside that range
+// return ErrValueOutOfRange wrapping n. WriteSigned is only supported on
+// HT16K33Seg7 panels.
+func (h *HT16K33Driver) WriteSigned(n int) error {
+	if h.panelType != HT16K33Seg7 {
+		return ErrWrongPanelType
+	}
+
+	if n > 9999 || n < -999 {
+		return &ErrValueOutOfRange{Value: n}
+	}
+
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	digits, err := splitNumberIntoDigits(n)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Clear(); err != nil {
+		return err
+	}
+
+	if neg {
+		if err := h.writeHexDigit(0, minusGlyph, false); err != nil {
+			return err
+		}
+	}
+
+	foundDigit := false
+	for pos, d := range digits {
+		if neg && pos == 0 {
+			continue
+		}
+
+		if d > 0 {
+			foundDigit = true
+		}
+
+		// Skip leading zeros
+		if !foundDigit {
+			continue
+		}
+
+		if err := h.WriteDigit(uint8(pos), d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteFloat displays f with decimals digits after the decimal point. The
+// point itself is rendered using bit 7 (the DP segment) of the digit that
+// precedes it, so it does not consume a position of its own; a negative f
+// reserves position 0 for a leading minus sign as in WriteSigned. If the
+// formatted value (sign included) does not fit in the 4 available
+// positions, ErrValueOutOfRange is returned wrapping f. WriteFloat is only
+// supported on HT16K33Seg7 panels.
+func (h *HT16K33Driver) WriteFloat(f float64, decimals int) error {
+	if h.panelType != HT16K33Seg7 {
+		return ErrWrongPanelType
+	}
+
+	neg := f < 0
+	abs := f
+	if neg {
+		abs = -f
+	}
+
+	s := strconv.FormatFloat(abs, 'f', decimals, 64)
+
+	maxDigits := 4
+	if neg {
+		maxDigits--
+	}
+	if len(strings.Replace(s, ".", "", 1)) > maxDigits {
+		return &ErrValueOutOfRange{Value: f}
+	}
+
+	// FormatFloat renders NaN/Inf as "NaN"/"+Inf"/"-Inf", which can slip
+	// past the length check above; reject anything that isn't a digit or a
+	// decimal point before it reaches the digit loop below.
+	for _, c := range s {
+		if c != '.' && (c < '0' || c > '9') {
+			return &ErrValueOutOfRange{Value: f}
+		}
+	}
+
+	if err := h.Clear(); err != nil {
+		return err
+	}
+
+	pos := uint8(0)
+	if neg {
+		if err := h.writeHexDigit(0, minusGlyph, false); err != nil {
+			return err
+		}
+		pos++
+	}
+
+	runes := []rune(s)
+	for i, c := range runes {
+		if c == '.' {
+			continue
+		}
+
+		d := int(c - '0')
+		dp := i+1 < len(runes) && runes[i+1] == '.'
+		if err := h.writeHexDigit(pos, hex[d], dp); err != nil {
+			return err
+		}
+		pos++
+	}
+
+	return nil
+}
+
 // WriteNumber displays a 4-digit number on the panel.  Leading zeros are not
 // shown.
 func (h *HT16K33Driver) WriteNumber(n int) error {
@@ -281,3 +624,130 @@ func splitNumberIntoDigits(n int) ([]int, error) {
 
 	return out, nil
 }
+
+// WriteString displays s across the 4 available positions, starting at
+// position 0. On a HT16K33Seg7 panel only the digits 0-9 can be rendered
+// (see WriteDigit); on a HT16K33Seg14 panel the full seg14Font range is
+// used, so letters and punctuation are supported, e.g. WriteString("Go!").
+// Characters beyond the 4th position, and characters with no glyph, are
+// skipped. WriteString is not supported on HT16K33Matrix8x8 panels.
+func (h *HT16K33Driver) WriteString(s string) error {
+	if h.panelType == HT16K33Matrix8x8 {
+		return ErrWrongPanelType
+	}
+
+	if err := h.Clear(); err != nil {
+		return err
+	}
+
+	pos := uint8(0)
+	for _, r := range s {
+		if pos > 3 {
+			break
+		}
+
+		if h.panelType == HT16K33Seg14 {
+			w, ok := seg14Glyph(r)
+			if !ok {
+				continue
+			}
+			if err := h.writeSeg14(pos, w); err != nil {
+				return err
+			}
+		} else {
+			d := int(r - '0')
+			if r < '0' || r > '9' {
+				continue
+			}
+			if err := h.WriteDigit(pos, d); err != nil {
+				return err
+			}
+		}
+
+		pos++
+	}
+
+	return nil
+}
+
+// seg14Glyph looks up the 14-segment encoding for r in seg14Font. Lowercase
+// letters are folded to their uppercase glyph, since the font only defines
+// one case per letter.
+func seg14Glyph(r rune) (uint16, bool) {
+	if r >= 'a' && r <= 'z' {
+		r -= 'a' - 'A'
+	}
+
+	idx := int(r) - ' '
+	if idx < 0 || idx >= len(seg14Font) {
+		return 0, false
+	}
+	return seg14Font[idx], true
+}
+
+// writeSeg14 writes a 14-segment word to the given position (0-3). Unlike
+// WriteDigit, positions are not offset to skip the colon, since the
+// alphanumeric backpack has no colon.
+func (h *HT16K33Driver) writeSeg14(pos uint8, w uint16) error {
+	if pos > 3 {
+		return ErrPositionOutOfRange
+	}
+	return h.connection.WriteWordData(pos*2, w)
+}
+
+// SetPixel sets the pixel at (x, y) on or off in the local matrix buffer.
+// Call Show to flush the buffer to the display. SetPixel is only supported
+// on HT16K33Matrix8x8 panels.
+func (h *HT16K33Driver) SetPixel(x, y uint8, on bool) error {
+	if h.panelType != HT16K33Matrix8x8 {
+		return ErrWrongPanelType
+	}
+	if x > 7 || y > 7 {
+		return ErrPositionOutOfRange
+	}
+
+	if on {
+		h.matrix[y] |= 1 << x
+	} else {
+		h.matrix[y] &^= 1 << x
+	}
+
+	return nil
+}
+
+// DrawImage sets the local matrix buffer from img, treating any non-black
+// pixel as on. Only the top-left 8x8 pixels of img are used. Call Show to
+// flush the buffer to the display. DrawImage is only supported on
+// HT16K33Matrix8x8 panels.
+func (h *HT16K33Driver) DrawImage(img image.Image) error {
+	if h.panelType != HT16K33Matrix8x8 {
+		return ErrWrongPanelType
+	}
+
+	b := img.Bounds()
+	for y := 0; y < 8 && y < b.Dy(); y++ {
+		for x := 0; x < 8 && x < b.Dx(); x++ {
+			r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			if err := h.SetPixel(uint8(x), uint8(y), r+g+bl > 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Show flushes the local matrix buffer to the 16-byte display RAM in a
+// single I2C burst. Show is only supported on HT16K33Matrix8x8 panels.
+func (h *HT16K33Driver) Show() error {
+	if h.panelType != HT16K33Matrix8x8 {
+		return ErrWrongPanelType
+	}
+
+	buf := make([]byte, 16)
+	for row, b := range h.matrix {
+		buf[row*2] = b
+	}
+
+	return h.connection.WriteBlockData(0x00, buf)
+}
@@ -3,6 +3,9 @@ package i2c
 import (
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
@@ -670,6 +673,429 @@ func TestHT16K33Driver_WriteNumber(t *testing.T) {
 	}
 }
 
+func TestHT16K33Driver_WithPanelType(t *testing.T) {
+	a := newI2cTestAdaptor()
+	h := NewHT16K33Driver(a, WithPanelType(HT16K33Seg14))
+
+	if h.panelType != HT16K33Seg14 {
+		t.Errorf("HT16K33Driver panelType = %v, want %v", h.panelType, HT16K33Seg14)
+	}
+}
+
+func TestHT16K33Driver_SetBlinkRate(t *testing.T) {
+	tests := []struct {
+		name string
+		rate BlinkRate
+		want uint8
+	}{
+		{
+			name: "off",
+			rate: BlinkOff,
+			want: ht16k33RegDisplay | ht16k33DisplayOn,
+		},
+		{
+			name: "2Hz",
+			rate: Blink2Hz,
+			want: ht16k33RegDisplay | ht16k33DisplayOn | (1 << 1),
+		},
+		{
+			name: "half Hz",
+			rate: BlinkHalfHz,
+			want: ht16k33RegDisplay | ht16k33DisplayOn | (3 << 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			h, a := initTestHT16K33DriverWithStubbedAdaptor()
+			h.Start()
+
+			a.i2cWriteImpl = func(got []byte) (int, error) {
+				if len(got) != 1 {
+					t.Errorf("Sequence error, expected 1 byte, got %d", len(got))
+				}
+				if !reflect.DeepEqual(got[0], tt.want) {
+					t.Logf("Sequence error, got %+v, expected %+v", got[0], tt.want)
+					return 0, fmt.Errorf("error")
+				}
+				return 0, nil
+			}
+
+			if err := h.SetBlinkRate(tt.rate); err != nil {
+				t.Errorf("HT16K33Driver.SetBlinkRate() error = %v, wantErr nil", err)
+			}
+		})
+	}
+}
+
+func TestHT16K33Driver_WriteString(t *testing.T) {
+	t.Run("seg7 digits", func(t *testing.T) {
+		h, a := initTestHT16K33DriverWithStubbedAdaptor()
+		h.Start()
+
+		var got []uint16
+		a.i2cWriteImpl = func(b []byte) (int, error) {
+			w, err := i2cWriteByteToWord(b)
+			if err != nil {
+				t.Fatalf("Sequence error, got error %v", err)
+			}
+			got = append(got, w)
+			return 0, nil
+		}
+
+		if err := h.WriteString("12"); err != nil {
+			t.Errorf("HT16K33Driver.WriteString() error = %v, wantErr nil", err)
+		}
+
+		want := []uint16{0x0000, 0x0000, 0x0000, 0x0000, 0x0000, digit[1], digit[2]}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("HT16K33Driver.WriteString() wrote %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("seg14 text", func(t *testing.T) {
+		h, a := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Seg14)(h)
+		h.Start()
+
+		var got []uint16
+		a.i2cWriteImpl = func(b []byte) (int, error) {
+			w, err := i2cWriteByteToWord(b)
+			if err != nil {
+				t.Fatalf("Sequence error, got error %v", err)
+			}
+			got = append(got, w)
+			return 0, nil
+		}
+
+		if err := h.WriteString("Go!"); err != nil {
+			t.Errorf("HT16K33Driver.WriteString() error = %v, wantErr nil", err)
+		}
+
+		want := []uint16{0x0000, 0x0000, 0x0000, 0x0000, seg14Font['G'-' '], seg14Font['O'-' '], seg14Font['!'-' ']}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("HT16K33Driver.WriteString() wrote %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("unsupported on matrix panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Matrix8x8)(h)
+		h.Start()
+
+		if err := h.WriteString("Go!"); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteString() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+}
+
+func TestHT16K33Driver_SetPixel(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	WithPanelType(HT16K33Matrix8x8)(h)
+	h.Start()
+
+	if err := h.SetPixel(3, 2, true); err != nil {
+		t.Errorf("HT16K33Driver.SetPixel() error = %v, wantErr nil", err)
+	}
+	if h.matrix[2] != 1<<3 {
+		t.Errorf("HT16K33Driver.SetPixel() matrix row = %08b, want %08b", h.matrix[2], 1<<3)
+	}
+
+	if err := h.SetPixel(8, 0, true); err != ErrPositionOutOfRange {
+		t.Errorf("HT16K33Driver.SetPixel() error = %v, want %v", err, ErrPositionOutOfRange)
+	}
+
+	h2, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	h2.Start()
+	if err := h2.SetPixel(0, 0, true); err != ErrWrongPanelType {
+		t.Errorf("HT16K33Driver.SetPixel() error = %v, want %v", err, ErrWrongPanelType)
+	}
+}
+
+func TestHT16K33Driver_DrawImage(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	WithPanelType(HT16K33Matrix8x8)(h)
+	h.Start()
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	img.Set(1, 0, color.White)
+
+	if err := h.DrawImage(img); err != nil {
+		t.Errorf("HT16K33Driver.DrawImage() error = %v, wantErr nil", err)
+	}
+	if h.matrix[0] != 1<<1 {
+		t.Errorf("HT16K33Driver.DrawImage() matrix row 0 = %08b, want %08b", h.matrix[0], 1<<1)
+	}
+}
+
+func TestHT16K33Driver_Show(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	WithPanelType(HT16K33Matrix8x8)(h)
+	h.Start()
+	h.SetPixel(0, 3, true)
+
+	var got []byte
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		got = b
+		return 0, nil
+	}
+
+	if err := h.Show(); err != nil {
+		t.Errorf("HT16K33Driver.Show() error = %v, wantErr nil", err)
+	}
+
+	want := make([]byte, 16)
+	want[3*2] = 1
+
+	// Show must flush the whole 16-byte display RAM in a single write, not
+	// one write per row.
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HT16K33Driver.Show() wrote %+v in a single burst, want %+v", got, want)
+	}
+}
+
+func TestHT16K33Driver_WriteHex(t *testing.T) {
+	tests := []struct {
+		name string
+		n    uint16
+		want []uint16
+	}{
+		{
+			name: "0x00FF",
+			n:    0x00FF,
+			want: []uint16{hex[0], hex[0], hex[0xF], hex[0xF]},
+		},
+		{
+			name: "0xBEEF",
+			n:    0xBEEF,
+			want: []uint16{hex[0xB], hex[0xE], hex[0xE], hex[0xF]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			h, a := initTestHT16K33DriverWithStubbedAdaptor()
+			h.Start()
+
+			var got []uint16
+			a.i2cWriteImpl = func(b []byte) (int, error) {
+				w, err := i2cWriteByteToWord(b)
+				if err != nil {
+					t.Fatalf("Sequence error, got error %v", err)
+				}
+				got = append(got, w)
+				return 0, nil
+			}
+
+			if err := h.WriteHex(tt.n); err != nil {
+				t.Errorf("HT16K33Driver.WriteHex() error = %v, wantErr nil", err)
+			}
+
+			// Skip the 5 zeros written by Clear().
+			if len(got) != 9 || !reflect.DeepEqual(got[5:], tt.want) {
+				t.Errorf("HT16K33Driver.WriteHex() wrote %+v, want last 4 = %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("unsupported on seg14 panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Seg14)(h)
+		h.Start()
+
+		if err := h.WriteHex(0x1234); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteHex() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+
+	t.Run("unsupported on matrix panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Matrix8x8)(h)
+		h.Start()
+
+		if err := h.WriteHex(0x1234); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteHex() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+}
+
+func TestHT16K33Driver_WriteSigned(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		want    []uint16
+		wantErr bool
+	}{
+		{
+			name: "42",
+			n:    42,
+			want: []uint16{0x0000, 0x0000, digit[4], digit[2]},
+		},
+		{
+			name: "-7",
+			n:    -7,
+			// Clear's last two zeros, then the sign at position 0 and the
+			// 7 at position 3.
+			want: []uint16{0x0000, 0x0000, minusGlyph, digit[7]},
+		},
+		{
+			name:    "too big",
+			n:       10000,
+			wantErr: true,
+		},
+		{
+			name:    "too negative",
+			n:       -1000,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			h, a := initTestHT16K33DriverWithStubbedAdaptor()
+			h.Start()
+
+			var got []uint16
+			a.i2cWriteImpl = func(b []byte) (int, error) {
+				w, err := i2cWriteByteToWord(b)
+				if err != nil {
+					t.Fatalf("Sequence error, got error %v", err)
+				}
+				got = append(got, w)
+				return 0, nil
+			}
+
+			err := h.WriteSigned(tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HT16K33Driver.WriteSigned() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) < 4 || !reflect.DeepEqual(got[len(got)-4:], tt.want) {
+				t.Errorf("HT16K33Driver.WriteSigned() wrote %+v, want last 4 = %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("unsupported on seg14 panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Seg14)(h)
+		h.Start()
+
+		if err := h.WriteSigned(42); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteSigned() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+
+	t.Run("unsupported on matrix panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Matrix8x8)(h)
+		h.Start()
+
+		if err := h.WriteSigned(42); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteSigned() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+}
+
+func TestHT16K33Driver_WriteFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        float64
+		decimals int
+		want     []uint16
+		wantErr  bool
+	}{
+		{
+			name:     "1.5",
+			f:        1.5,
+			decimals: 1,
+			want:     []uint16{digit[1] | 1<<7, digit[5]},
+		},
+		{
+			name:     "-2.5",
+			f:        -2.5,
+			decimals: 1,
+			want:     []uint16{minusGlyph, digit[2] | 1<<7, digit[5]},
+		},
+		{
+			name:     "too big",
+			f:        12345,
+			decimals: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "NaN",
+			f:        math.NaN(),
+			decimals: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "+Inf",
+			f:        math.Inf(1),
+			decimals: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "-Inf",
+			f:        math.Inf(-1),
+			decimals: 0,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			h, a := initTestHT16K33DriverWithStubbedAdaptor()
+			h.Start()
+
+			var got []uint16
+			a.i2cWriteImpl = func(b []byte) (int, error) {
+				w, err := i2cWriteByteToWord(b)
+				if err != nil {
+					t.Fatalf("Sequence error, got error %v", err)
+				}
+				got = append(got, w)
+				return 0, nil
+			}
+
+			err := h.WriteFloat(tt.f, tt.decimals)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HT16K33Driver.WriteFloat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) < len(tt.want) || !reflect.DeepEqual(got[len(got)-len(tt.want):], tt.want) {
+				t.Errorf("HT16K33Driver.WriteFloat() wrote %+v, want last %d = %+v", got, len(tt.want), tt.want)
+			}
+		})
+	}
+
+	t.Run("unsupported on seg14 panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Seg14)(h)
+		h.Start()
+
+		if err := h.WriteFloat(1.5, 1); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteFloat() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+
+	t.Run("unsupported on matrix panel", func(t *testing.T) {
+		h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+		WithPanelType(HT16K33Matrix8x8)(h)
+		h.Start()
+
+		if err := h.WriteFloat(1.5, 1); err != ErrWrongPanelType {
+			t.Errorf("HT16K33Driver.WriteFloat() error = %v, want %v", err, ErrWrongPanelType)
+		}
+	})
+}
+
 func Test_splitNumberIntoDigits(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,140 @@
+package i2c
+
+import (
+	"errors"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// ErrAlreadyPollingKeys is returned by PollKeys when it is called while a
+// previous poll loop is still running.
+var ErrAlreadyPollingKeys = errors.New("already polling keys")
+
+// KeyEvent identifies a single key in the HT16K33's 13x3 key-scan matrix,
+// carried as the Data of "button.press" and "button.release" events.
+type KeyEvent struct {
+	Row uint8
+	Col uint8
+}
+
+// ReadKeys reads the 6-byte key data registers (0x40-0x45), returning the
+// state of every input in the 13x3 key-scan matrix, indexed as
+// keys[row*3+col].
+func (h *HT16K33Driver) ReadKeys() ([]bool, error) {
+	buf := make([]byte, 6)
+	if _, err := h.connection.ReadBlockData(ht16k33RegKeyData, buf); err != nil {
+		return nil, err
+	}
+
+	keys := make([]bool, 13*3)
+	for col := 0; col < 3; col++ {
+		word := uint16(buf[col*2]) | uint16(buf[col*2+1])<<8
+		for row := 0; row < 13; row++ {
+			keys[row*3+col] = word&(1<<uint(row)) != 0
+		}
+	}
+
+	return keys, nil
+}
+
+// PollKeys starts a background goroutine that reads the key matrix every
+// interval, diffing successive reads and publishing gobot.Event{Name:
+// "button.press", Data: KeyEvent{Row, Col}} (and "button.release") for every
+// key that changed state. Only one poll loop may run at a time; call
+// StopPollingKeys, or Halt, to stop it.
+func (h *HT16K33Driver) PollKeys(interval time.Duration) error {
+	h.pollMutex.Lock()
+	if h.pollHalt != nil {
+		h.pollMutex.Unlock()
+		return ErrAlreadyPollingKeys
+	}
+	halt := make(chan struct{})
+	h.pollHalt = halt
+	h.pollMutex.Unlock()
+
+	last, err := h.ReadKeys()
+	if err != nil {
+		h.pollMutex.Lock()
+		h.pollHalt = nil
+		h.pollMutex.Unlock()
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-halt:
+				return
+			case <-ticker.C:
+				keys, err := h.ReadKeys()
+				if err != nil {
+					continue
+				}
+				h.publishKeyDiff(last, keys)
+				last = keys
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopPollingKeys stops a running PollKeys loop. It is a no-op if no loop is
+// running.
+func (h *HT16K33Driver) StopPollingKeys() {
+	h.pollMutex.Lock()
+	halt := h.pollHalt
+	h.pollHalt = nil
+	h.pollMutex.Unlock()
+
+	if halt != nil {
+		close(halt)
+	}
+}
+
+// WithKeyInterrupt switches key scanning from polling to edge-triggered:
+// whenever irq (typically a gpio ButtonDriver wired to the HT16K33's IRQ
+// pin) publishes a "push" event, the driver reads the INT register (0x60)
+// to acknowledge the interrupt, re-reads the key matrix, and publishes any
+// resulting button.press/button.release events.
+func (h *HT16K33Driver) WithKeyInterrupt(irq gobot.Eventer) error {
+	last, err := h.ReadKeys()
+	if err != nil {
+		return err
+	}
+
+	return irq.On("push", func(interface{}) {
+		if _, err := h.connection.ReadByteData(ht16k33RegInt); err != nil {
+			return
+		}
+
+		keys, err := h.ReadKeys()
+		if err != nil {
+			return
+		}
+
+		h.publishKeyDiff(last, keys)
+		last = keys
+	})
+}
+
+// publishKeyDiff publishes button.press/button.release for every key whose
+// state differs between last and keys.
+func (h *HT16K33Driver) publishKeyDiff(last, keys []bool) {
+	for i := range keys {
+		if i >= len(last) || keys[i] == last[i] {
+			continue
+		}
+
+		evt := KeyEvent{Row: uint8(i / 3), Col: uint8(i % 3)}
+		if keys[i] {
+			h.Publish("button.press", evt)
+		} else {
+			h.Publish("button.release", evt)
+		}
+	}
+}
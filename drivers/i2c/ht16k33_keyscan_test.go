@@ -0,0 +1,146 @@
+package i2c
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+func TestHT16K33Driver_ReadKeys(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+
+	keys, err := h.ReadKeys()
+	if err != nil {
+		t.Fatalf("HT16K33Driver.ReadKeys() error = %v, wantErr nil", err)
+	}
+	if len(keys) != 13*3 {
+		t.Errorf("HT16K33Driver.ReadKeys() returned %d keys, want %d", len(keys), 13*3)
+	}
+}
+
+func TestHT16K33Driver_PollKeys(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+
+	// row 0, col 1 goes from released to pressed on the second read, then
+	// back to released on the third.
+	reads := 0
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		reads++
+		if reads == 2 {
+			b[2] = 0x01
+		}
+		return len(b), nil
+	}
+
+	var mu sync.Mutex
+	var pressed, released []KeyEvent
+	pressCh := make(chan struct{}, 10)
+	releaseCh := make(chan struct{}, 10)
+	h.On("button.press", func(data interface{}) {
+		mu.Lock()
+		pressed = append(pressed, data.(KeyEvent))
+		mu.Unlock()
+		pressCh <- struct{}{}
+	})
+	h.On("button.release", func(data interface{}) {
+		mu.Lock()
+		released = append(released, data.(KeyEvent))
+		mu.Unlock()
+		releaseCh <- struct{}{}
+	})
+
+	if err := h.PollKeys(time.Millisecond); err != nil {
+		t.Fatalf("HT16K33Driver.PollKeys() error = %v, wantErr nil", err)
+	}
+
+	if err := h.PollKeys(time.Millisecond); err != ErrAlreadyPollingKeys {
+		t.Errorf("HT16K33Driver.PollKeys() error = %v, want %v", err, ErrAlreadyPollingKeys)
+	}
+
+	select {
+	case <-pressCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected a button.press event")
+	}
+	select {
+	case <-releaseCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected a button.release event")
+	}
+
+	h.StopPollingKeys()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := KeyEvent{Row: 0, Col: 1}
+	if len(pressed) == 0 || pressed[0] != want {
+		t.Errorf("PollKeys() published presses = %v, want first = %v", pressed, want)
+	}
+	if len(released) == 0 || released[0] != want {
+		t.Errorf("PollKeys() published releases = %v, want first = %v", released, want)
+	}
+
+	// Stopping twice, and halting after stopping, should both be no-ops.
+	h.StopPollingKeys()
+	if err := h.Halt(); err != nil {
+		t.Errorf("HT16K33Driver.Halt() error = %v, wantErr nil", err)
+	}
+
+	// Polling again after stopping should succeed.
+	if err := h.PollKeys(time.Millisecond); err != nil {
+		t.Errorf("HT16K33Driver.PollKeys() error = %v, wantErr nil", err)
+	}
+	h.StopPollingKeys()
+}
+
+func TestHT16K33Driver_WithKeyInterrupt(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+
+	// row 2, col 0 is already pressed by the time WithKeyInterrupt takes its
+	// initial read, then releases on the first "push" event.
+	pressed := true
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		if pressed {
+			b[0] = 1 << 2
+		}
+		return len(b), nil
+	}
+
+	irq := gobot.NewEventer()
+	irq.AddEvent("push")
+
+	if err := h.WithKeyInterrupt(irq); err != nil {
+		t.Fatalf("HT16K33Driver.WithKeyInterrupt() error = %v, wantErr nil", err)
+	}
+
+	var mu sync.Mutex
+	var got KeyEvent
+	releaseCh := make(chan struct{}, 1)
+	h.On("button.release", func(data interface{}) {
+		mu.Lock()
+		got = data.(KeyEvent)
+		mu.Unlock()
+		releaseCh <- struct{}{}
+	})
+
+	pressed = false
+	irq.Publish("push", nil)
+
+	select {
+	case <-releaseCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected a button.release event")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := KeyEvent{Row: 2, Col: 0}
+	if got != want {
+		t.Errorf("WithKeyInterrupt() published release = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,272 @@
+package i2c
+
+import (
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// ScrollMode controls how a Scroller behaves once the text reaches either
+// end of the display.
+type ScrollMode uint8
+
+const (
+	// ScrollLoop restarts from the beginning once the last glyph has reached
+	// the leftmost position, and is the default.
+	ScrollLoop ScrollMode = iota
+	// ScrollOnce stops, publishing "scroll.done", once the last glyph has
+	// reached the leftmost position; that last window stays on the display.
+	ScrollOnce
+	// ScrollBounce reverses direction at each end instead of wrapping.
+	ScrollBounce
+)
+
+// ScrollOption configures a Scroller. See NewScroller.
+type ScrollOption func(*Scroller)
+
+// WithScrollRate sets the interval between scroll ticks.
+func WithScrollRate(d time.Duration) ScrollOption {
+	return func(s *Scroller) { s.rate = d }
+}
+
+// WithScrollMode sets the scroller's end-of-text behaviour.
+func WithScrollMode(m ScrollMode) ScrollOption {
+	return func(s *Scroller) { s.mode = m }
+}
+
+// Scroller drives a 4-position HT16K33Driver panel as a non-blocking
+// marquee: a background goroutine feeds a ring buffer of glyph words
+// through the display window at a configurable rate, only writing the
+// 8-byte display RAM window when it actually changes. It publishes
+// "scroll.tick", "scroll.wrap" and "scroll.done" via the standard
+// gobot.Eventer interface.
+type Scroller struct {
+	gobot.Eventer
+
+	h    *HT16K33Driver
+	rate time.Duration
+	mode ScrollMode
+
+	mutex   sync.Mutex
+	glyphs  []uint16
+	window  [4]uint16
+	pos     int
+	dir     int
+	running bool
+	halt    chan struct{}
+}
+
+// NewScroller creates a Scroller driving h.
+// Optional params:
+//		i2c.WithScrollRate(time.Duration):	interval between scroll ticks, defaults to 200ms
+//		i2c.WithScrollMode(ScrollMode):	end-of-text behaviour, defaults to ScrollLoop
+//
+func NewScroller(h *HT16K33Driver, opts ...ScrollOption) *Scroller {
+	s := &Scroller{
+		Eventer: gobot.NewEventer(),
+		h:       h,
+		rate:    200 * time.Millisecond,
+		mode:    ScrollLoop,
+		dir:     1,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.AddEvent("scroll.tick")
+	s.AddEvent("scroll.wrap")
+	s.AddEvent("scroll.done")
+
+	return s
+}
+
+// SetRate changes the interval between scroll ticks. It takes effect on the
+// next tick.
+func (s *Scroller) SetRate(d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rate = d
+}
+
+// SetMode changes the scroller's end-of-text behaviour.
+func (s *Scroller) SetMode(m ScrollMode) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.mode = m
+}
+
+// Start begins scrolling text through the display in a background
+// goroutine, replacing any text already scrolling. The marquee only makes
+// sense on the 4-position digit displays, so Start returns ErrWrongPanelType
+// and does nothing on HT16K33Matrix8x8 panels.
+func (s *Scroller) Start(text string) error {
+	if s.h.panelType == HT16K33Matrix8x8 {
+		return ErrWrongPanelType
+	}
+
+	s.Stop()
+
+	s.mutex.Lock()
+	s.glyphs = glyphWords(s.h.panelType, text)
+	s.window = [4]uint16{}
+	s.pos = 0
+	s.dir = 1
+	s.running = true
+	s.halt = make(chan struct{})
+	halt := s.halt
+	s.mutex.Unlock()
+
+	go s.run(halt)
+
+	return nil
+}
+
+// Stop halts the scroll goroutine, leaving the display showing whatever was
+// last pushed.
+func (s *Scroller) Stop() {
+	s.mutex.Lock()
+	if !s.running {
+		s.mutex.Unlock()
+		return
+	}
+	s.running = false
+	halt := s.halt
+	s.mutex.Unlock()
+
+	close(halt)
+}
+
+// Halt stops the scroller and clears the display.
+func (s *Scroller) Halt() error {
+	s.Stop()
+	return s.h.Clear()
+}
+
+// run feeds the display at the configured rate until halt is closed. The
+// rate is re-read every tick so SetRate takes effect without restarting.
+func (s *Scroller) run(halt chan struct{}) {
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(s.currentRate()):
+			if !s.tick() {
+				return
+			}
+		}
+	}
+}
+
+func (s *Scroller) currentRate() time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rate
+}
+
+// tick advances the display window by one glyph, pushing it to the panel
+// only if it changed. It returns false once scrolling has finished, which
+// only happens in ScrollOnce mode.
+func (s *Scroller) tick() bool {
+	s.mutex.Lock()
+	glyphs := s.glyphs
+	pos := s.pos
+	dir := s.dir
+	mode := s.mode
+	s.mutex.Unlock()
+
+	if len(glyphs) == 0 {
+		return true
+	}
+
+	wrapped := false
+	done := false
+	next := pos + dir
+	if next < 0 || next >= len(glyphs) {
+		switch mode {
+		case ScrollOnce:
+			done = true
+			next = pos
+		case ScrollBounce:
+			dir = -dir
+			next = pos + dir
+		default: // ScrollLoop
+			next = 0
+			wrapped = true
+		}
+	}
+
+	window := windowAt(glyphs, pos)
+
+	s.mutex.Lock()
+	s.pos = next
+	s.dir = dir
+	changed := window != s.window
+	if changed {
+		s.window = window
+	}
+	s.mutex.Unlock()
+
+	if changed {
+		s.push(window)
+	}
+
+	s.Publish("scroll.tick", pos)
+	if wrapped {
+		s.Publish("scroll.wrap", nil)
+	}
+	if done {
+		s.Publish("scroll.done", nil)
+		return false
+	}
+
+	return true
+}
+
+// push flushes window to the display's 4 digit positions. Like WriteString,
+// it dispatches on the driver's panel type, since Seg14 positions are not
+// offset to skip the colon the way Seg7 positions are.
+func (s *Scroller) push(window [4]uint16) {
+	for pos, w := range window {
+		if s.h.panelType == HT16K33Seg14 {
+			_ = s.h.writeSeg14(uint8(pos), w)
+			continue
+		}
+		_ = s.h.writeHexDigit(uint8(pos), w, false)
+	}
+}
+
+// windowAt returns the 4-glyph slice of glyphs starting at pos, zero-padding
+// positions that fall outside glyphs. Scrolling starts at pos 0, so the text
+// does not scroll in from the right, and ScrollLoop and ScrollOnce go no
+// further than the last glyph, so the text does not scroll fully off.
+func windowAt(glyphs []uint16, pos int) [4]uint16 {
+	var w [4]uint16
+	for i := range w {
+		idx := pos + i
+		if idx >= 0 && idx < len(glyphs) {
+			w[i] = glyphs[idx]
+		}
+	}
+	return w
+}
+
+// glyphWords converts text into the glyph words for the driver's panel
+// type, dropping characters with no glyph.
+func glyphWords(t PanelType, text string) []uint16 {
+	glyphs := make([]uint16, 0, len(text))
+	for _, r := range text {
+		if t == HT16K33Seg14 {
+			if w, ok := seg14Glyph(r); ok {
+				glyphs = append(glyphs, w)
+			}
+			continue
+		}
+
+		if r >= '0' && r <= '9' {
+			glyphs = append(glyphs, digit[r-'0'])
+		}
+	}
+	return glyphs
+}
@@ -0,0 +1,184 @@
+package i2c
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewScroller(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	s := NewScroller(h)
+
+	if s.rate != 200*time.Millisecond {
+		t.Errorf("NewScroller() rate = %v, want %v", s.rate, 200*time.Millisecond)
+	}
+	if s.mode != ScrollLoop {
+		t.Errorf("NewScroller() mode = %v, want %v", s.mode, ScrollLoop)
+	}
+}
+
+func TestScroller_SetRate(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	s := NewScroller(h)
+
+	s.SetRate(50 * time.Millisecond)
+	if got := s.currentRate(); got != 50*time.Millisecond {
+		t.Errorf("Scroller.SetRate() rate = %v, want %v", got, 50*time.Millisecond)
+	}
+}
+
+func TestScroller_SetMode(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	s := NewScroller(h)
+
+	s.SetMode(ScrollOnce)
+	if s.mode != ScrollOnce {
+		t.Errorf("Scroller.SetMode() mode = %v, want %v", s.mode, ScrollOnce)
+	}
+}
+
+func TestScroller_StartStop(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+	a.i2cWriteImpl = func(b []byte) (int, error) { return 0, nil }
+
+	s := NewScroller(h, WithScrollRate(time.Millisecond))
+
+	var mu sync.Mutex
+	ticks := 0
+	tickCh := make(chan struct{}, 10)
+	s.On("scroll.tick", func(interface{}) {
+		mu.Lock()
+		ticks++
+		mu.Unlock()
+		select {
+		case tickCh <- struct{}{}:
+		default:
+		}
+	})
+
+	if err := s.Start("42"); err != nil {
+		t.Fatalf("Scroller.Start() error = %v, wantErr nil", err)
+	}
+	select {
+	case <-tickCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected at least one scroll.tick event")
+	}
+
+	s.Stop()
+
+	mu.Lock()
+	got := ticks
+	mu.Unlock()
+	if got == 0 {
+		t.Errorf("Scroller ticks = %d, want > 0", got)
+	}
+}
+
+func TestScroller_ScrollOnceDone(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+	a.i2cWriteImpl = func(b []byte) (int, error) { return 0, nil }
+
+	s := NewScroller(h, WithScrollRate(time.Millisecond), WithScrollMode(ScrollOnce))
+
+	done := make(chan struct{})
+	s.On("scroll.done", func(interface{}) { close(done) })
+
+	if err := s.Start("1"); err != nil {
+		t.Fatalf("Scroller.Start() error = %v, wantErr nil", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected scroll.done event")
+	}
+}
+
+func TestScroller_Halt(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+
+	cleared := 0
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		cleared++
+		return 0, nil
+	}
+
+	s := NewScroller(h, WithScrollRate(time.Millisecond))
+	if err := s.Start("42"); err != nil {
+		t.Fatalf("Scroller.Start() error = %v, wantErr nil", err)
+	}
+
+	if err := s.Halt(); err != nil {
+		t.Errorf("Scroller.Halt() error = %v, wantErr nil", err)
+	}
+	if cleared == 0 {
+		t.Errorf("Scroller.Halt() did not write to display")
+	}
+}
+
+func TestScroller_Seg14Panel(t *testing.T) {
+	h, a := initTestHT16K33DriverWithStubbedAdaptor()
+	WithPanelType(HT16K33Seg14)(h)
+	h.Start()
+
+	var mu sync.Mutex
+	var got []uint16
+	a.i2cWriteImpl = func(b []byte) (int, error) {
+		w, err := i2cWriteByteToWord(b)
+		if err != nil {
+			return 0, err
+		}
+		mu.Lock()
+		got = append(got, w)
+		mu.Unlock()
+		return 0, nil
+	}
+
+	s := NewScroller(h, WithScrollRate(time.Millisecond))
+	tickCh := make(chan struct{}, 10)
+	s.On("scroll.tick", func(interface{}) {
+		select {
+		case tickCh <- struct{}{}:
+		default:
+		}
+	})
+
+	if err := s.Start("ABCD"); err != nil {
+		t.Fatalf("Scroller.Start() error = %v, wantErr nil", err)
+	}
+	select {
+	case <-tickCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected at least one scroll.tick event")
+	}
+
+	s.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := seg14Font['A'-' ']
+	found := false
+	for _, w := range got {
+		if w == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Scroller on a seg14 panel wrote %+v, want it to include the seg14Font glyph %#04x for 'A'", got, want)
+	}
+}
+
+func TestScroller_StartWrongPanelType(t *testing.T) {
+	h, _ := initTestHT16K33DriverWithStubbedAdaptor()
+	h.Start()
+	WithPanelType(HT16K33Matrix8x8)(h)
+
+	s := NewScroller(h, WithScrollRate(time.Millisecond))
+	if err := s.Start("42"); err != ErrWrongPanelType {
+		t.Errorf("Scroller.Start() error = %v, want %v", err, ErrWrongPanelType)
+	}
+}